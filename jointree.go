@@ -0,0 +1,122 @@
+// Copyright 2021 FerretDB Inc.
+// Copyright 2025 Alexey Palazhchenko.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lazyerrors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// lazyerrorTree adds one or more program counters to a list of joined errors,
+// keeping them as independent branches instead of collapsing them into a linear chain.
+type lazyerrorTree struct {
+	errs []error
+	pcs  []uintptr
+}
+
+// Error implements the [error] interface.
+//
+// It returns the joined errors' messages, separated by "; ", with location information
+// for the first captured frame prefixed.
+func (let lazyerrorTree) Error() string {
+	msgs := make([]string, len(let.errs))
+	for i, err := range let.errs {
+		msgs[i] = err.Error()
+	}
+
+	msg := strings.Join(msgs, "; ")
+
+	frames := framesFor(let.pcs)
+	if len(frames) == 0 {
+		return msg
+	}
+
+	frame := frames[0]
+
+	return fmt.Sprintf(
+		Format,
+		shortPath(frame.File, FileSegments),
+		frame.Line,
+		shortPath(frame.Function, FunctionSegments),
+		msg,
+	)
+}
+
+// GoString implements the [fmt.GoStringer] interface.
+//
+// It exists so `%#v` fmt verb could correctly print joined errors.
+func (let lazyerrorTree) GoString() string {
+	return fmt.Sprintf("lazyerrorTree{%q}", let.Error())
+}
+
+// Format implements the [fmt.Formatter] interface.
+//
+// `%s` and `%v` behave the same as [lazyerrorTree.Error]. `%#v` behaves the same as [lazyerrorTree.GoString].
+// `%+v` additionally walks the captured frames, then renders each joined error on its own indented line,
+// recursing with `%+v` so a branch that is itself a [lazyerror] or [lazyerrorTree] prints its own trace too.
+func (let lazyerrorTree) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			io.WriteString(f, let.GoString()) //nolint:errcheck // f is a fmt.State, write never fails
+			return
+
+		case f.Flag('+'):
+			io.WriteString(f, let.Error()) //nolint:errcheck // f is a fmt.State, write never fails
+			io.WriteString(f, "\n")        //nolint:errcheck // f is a fmt.State, write never fails
+
+			for _, frame := range framesFor(let.pcs) {
+				fmt.Fprintf(f, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			}
+
+			for _, err := range let.errs {
+				indented := strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n\t")
+				fmt.Fprintf(f, "\t%s\n", indented)
+			}
+
+			return
+		}
+
+		fallthrough
+
+	case 's':
+		io.WriteString(f, let.Error()) //nolint:errcheck // f is a fmt.State, write never fails
+
+	case 'q':
+		fmt.Fprintf(f, "%q", let.Error())
+	}
+}
+
+// Unwrap returns the joined errors, as passed to [JoinTree], for [errors.Is] and [errors.As]
+// to visit each branch independently.
+func (let lazyerrorTree) Unwrap() []error {
+	return let.errs
+}
+
+// check interfaces
+var (
+	_ error                         = &lazyerrorTree{}
+	_ fmt.GoStringer                = &lazyerrorTree{}
+	_ fmt.Formatter                 = &lazyerrorTree{}
+	_ interface{ Unwrap() []error } = &lazyerrorTree{}
+
+	_ error                         = lazyerrorTree{}
+	_ fmt.GoStringer                = lazyerrorTree{}
+	_ fmt.Formatter                 = lazyerrorTree{}
+	_ interface{ Unwrap() []error } = lazyerrorTree{}
+)
@@ -16,7 +16,10 @@
 package lazyerrors
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 	"runtime"
 	"strings"
 )
@@ -44,43 +47,70 @@ var (
 	//   4. Original error.
 	// Explicit argument indexes could be used; for example, to completely remove file and line, use "%[3]s: %[4]s".
 	Format = "%s:%d (%s): %s"
+
+	// StackDepth specifies how many program counters are captured by [New], [Error], [Errorf], and [Join].
+	// The default value of 1 preserves the original single-frame behavior.
+	// Set it to a larger value to capture a full call stack, printed by the `%+v` [fmt] verb.
+	// Use [NewStack] or [ErrorStack] to capture a different depth for a single call
+	// without changing this package-level default.
+	StackDepth = 1
 )
 
-// lazyerror adds a single program counter to the wrapped error.
+// lazyerror adds one or more program counters to the wrapped error.
 //
 // TODO https://github.com/AlekSi/lazyerrors/issues/1
 type lazyerror struct {
 	err error
-	pc  uintptr
+	pcs []uintptr
 }
 
-// loc returns file, line and function name for the stored program counter.
+// loc returns frames for the stored program counters, innermost (closest to the capture point) first.
+// It returns nil if no program counter was captured.
 //
 // Should it return original or shortened paths?
 // TODO https://github.com/AlekSi/lazyerrors/issues/1
-func (le lazyerror) loc() (file string, line int, function string) {
-	if le.pc == 0 {
-		return
+func (le lazyerror) loc() []runtime.Frame {
+	return framesFor(le.pcs)
+}
+
+// framesFor returns frames for the given program counters, innermost (closest to the capture point) first.
+// It returns nil if pcs is empty.
+func framesFor(pcs []uintptr) []runtime.Frame {
+	if len(pcs) == 0 {
+		return nil
 	}
 
-	frame, _ := runtime.CallersFrames([]uintptr{le.pc}).Next()
-	return frame.File, frame.Line, frame.Function
+	frames := runtime.CallersFrames(pcs)
+
+	res := make([]runtime.Frame, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		res = append(res, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	return res
 }
 
 // Error implements the [error] interface.
 //
-// It returns the wrapped error's message with location information.
+// It returns the wrapped error's message with location information for the first captured frame.
 func (le lazyerror) Error() string {
-	file, line, function := le.loc()
-	if file == "" && function == "" {
+	frames := le.loc()
+	if len(frames) == 0 {
 		return le.err.Error()
 	}
 
+	frame := frames[0]
+
 	return fmt.Sprintf(
 		Format,
-		shorten(file, FileSegments),
-		line,
-		shorten(function, FunctionSegments),
+		shortPath(frame.File, FileSegments),
+		frame.Line,
+		shortPath(frame.Function, FunctionSegments),
 		le.err.Error(),
 	)
 }
@@ -92,15 +122,88 @@ func (le lazyerror) GoString() string {
 	return fmt.Sprintf("lazyerror{%q}", le.Error())
 }
 
+// Format implements the [fmt.Formatter] interface.
+//
+// `%s` and `%v` behave the same as [lazyerror.Error]. `%#v` behaves the same as [lazyerror.GoString].
+// `%+v` additionally walks the captured frames (and those of any wrapped [lazyerror]),
+// pkg/errors-style, printing each frame's function, file, and line on its own line.
+func (le lazyerror) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			io.WriteString(f, le.GoString()) //nolint:errcheck // f is a fmt.State, write never fails
+			return
+
+		case f.Flag('+'):
+			io.WriteString(f, le.Error()) //nolint:errcheck // f is a fmt.State, write never fails
+			io.WriteString(f, "\n")       //nolint:errcheck // f is a fmt.State, write never fails
+			le.writeFrames(f)
+
+			return
+		}
+
+		fallthrough
+
+	case 's':
+		io.WriteString(f, le.Error()) //nolint:errcheck // f is a fmt.State, write never fails
+
+	case 'q':
+		fmt.Fprintf(f, "%q", le.Error())
+	}
+}
+
+// writeFrames writes le's captured frames, one per line, then recurses into the first [lazyerror]
+// found in the wrapped error's chain (the wrapped error itself included), so a chain built with
+// `Errorf("...: %w", inner)` or `Error(inner)` renders as a full multi-frame trace.
+func (le lazyerror) writeFrames(w io.Writer) {
+	for _, frame := range le.loc() {
+		fmt.Fprintf(w, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+
+	for next := le.err; next != nil; next = errors.Unwrap(next) {
+		if inner, ok := next.(lazyerror); ok {
+			inner.writeFrames(w)
+			return
+		}
+	}
+}
+
 // Unwrap returns the wrapped error.
 func (le lazyerror) Unwrap() error {
 	return le.err
 }
 
-// shorten returns the shortened form of the given path.
+// Is implements the `Is(error) bool` interface used by [errors.Is].
+//
+// It is needed because le.pcs is a slice, making lazyerror not comparable with `==`;
+// without it, [errors.Is] would silently stop matching a lazyerror value against itself
+// further down a wrap chain. le.err is compared with [reflect.DeepEqual] rather than `==`
+// because it may itself be a non-comparable lazyerror (e.g. `Error(New("err"))`), which
+// would otherwise make `==` panic at runtime.
+func (le lazyerror) Is(target error) bool {
+	other, ok := target.(lazyerror)
+	if !ok {
+		return false
+	}
+
+	if !reflect.DeepEqual(le.err, other.err) || len(le.pcs) != len(other.pcs) {
+		return false
+	}
+
+	for i, pc := range le.pcs {
+		if pc != other.pcs[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shortPath returns the shortened form of the given path.
 //
 // TODO https://github.com/AlekSi/lazyerrors/issues/1
-func shorten(path string, segments int) string {
+func shortPath(path string, segments int) string {
 	switch {
 	case segments == 0:
 		return ""
@@ -124,11 +227,15 @@ func shorten(path string, segments int) string {
 var (
 	_ error                       = &lazyerror{}
 	_ fmt.GoStringer              = &lazyerror{}
+	_ fmt.Formatter               = &lazyerror{}
 	_ interface{ Unwrap() error } = &lazyerror{}
+	_ interface{ Is(error) bool } = &lazyerror{}
 
 	// Should the receiver be a value?
 	// TODO https://github.com/AlekSi/lazyerrors/issues/1
 	_ error                       = lazyerror{}
 	_ fmt.GoStringer              = lazyerror{}
+	_ fmt.Formatter               = lazyerror{}
 	_ interface{ Unwrap() error } = lazyerror{}
+	_ interface{ Is(error) bool } = lazyerror{}
 )
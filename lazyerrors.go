@@ -17,14 +17,17 @@
 // file path, line number, and function/method name.
 //
 // [New], [Error], [Errorf], and [Join] functions create a new error
-// with location captured as a single uintptr for Program Counter (PC).
+// with location captured as a single uintptr for Program Counter (PC)
+// by default, controlled by the package-level [StackDepth] variable.
 //
-// Only one location is captured for each error value, not a full call stack.
-// If the "return stack" is needed, use the functions mentioned above
-// with each return statement, channel operations, etc.
+// Only one location is captured for each error value by default, not a full call stack.
+// If the "return stack" is needed, either set [StackDepth] to a value larger than 1,
+// or use [NewStack] / [ErrorStack] to capture it for a single call,
+// or use the functions mentioned above with each return statement, channel operations, etc.
 //
 // Actual error formatting happens lazily in the `Error() string` method,
 // and can be changed by setting [FileSegments], [FunctionSegments], and [Format] variables.
+// The `%+v` [fmt] verb renders the full captured call stack instead.
 package lazyerrors
 
 import (
@@ -33,15 +36,23 @@ import (
 	"runtime"
 )
 
-// New returns an error created with [errors.New] wrapped with a single location.
+// New returns an error created with [errors.New] wrapped with location captured per [StackDepth].
 func New(s string) error {
 	return lazyerror{
 		err: errors.New(s),
-		pc:  pc(),
+		pcs: pcs(3, StackDepth),
 	}
 }
 
-// Error returns an error wrapped with a single location.
+// NewStack returns an error created with [errors.New] wrapped with a call stack of up to depth frames.
+func NewStack(s string, depth int) error {
+	return lazyerror{
+		err: errors.New(s),
+		pcs: pcs(3, depth),
+	}
+}
+
+// Error returns an error wrapped with location captured per [StackDepth].
 func Error(err error) error {
 	if err == nil {
 		panic("err is nil")
@@ -49,23 +60,35 @@ func Error(err error) error {
 
 	return lazyerror{
 		err: err,
-		pc:  pc(),
+		pcs: pcs(3, StackDepth),
 	}
 }
 
-// Errorf returns an error created with [fmt.Errorf] wrapped with a single location.
+// ErrorStack returns err wrapped with a call stack of up to depth frames.
+func ErrorStack(err error, depth int) error {
+	if err == nil {
+		panic("err is nil")
+	}
+
+	return lazyerror{
+		err: err,
+		pcs: pcs(3, depth),
+	}
+}
+
+// Errorf returns an error created with [fmt.Errorf] wrapped with location captured per [StackDepth].
 func Errorf(format string, a ...any) error {
 	return lazyerror{
 		err: fmt.Errorf(format, a...),
-		pc:  pc(),
+		pcs: pcs(3, StackDepth),
 	}
 }
 
-// Join returns an error created with [errors.Join] wrapped with a single location.
+// Join returns an error created with [errors.Join] wrapped with location captured per [StackDepth].
 //
 // Any nil error values are discarded, and nil is returned if no values are left.
 // But unlike [errors.Join], a non-nil error returned implements the `Unwrap() error` method,
-// not `Unwrap() []error`.
+// not `Unwrap() []error`. Use [JoinTree] if the latter is needed.
 func Join(errs ...error) error {
 	err := errors.Join(errs...)
 	if err == nil {
@@ -74,14 +97,46 @@ func Join(errs ...error) error {
 
 	return lazyerror{
 		err: err,
-		pc:  pc(),
+		pcs: pcs(3, StackDepth),
 	}
 }
 
-// pc returns a program counter of the caller's caller.
-func pc() uintptr {
-	pc := make([]uintptr, 1)
-	runtime.Callers(3, pc)
+// JoinTree returns an error joining the given errors, preserving each one as an independent branch,
+// with location captured per [StackDepth].
+//
+// Unlike [Join], which collapses to a linear `Unwrap() error` chain via [errors.Join],
+// a non-nil error returned by JoinTree implements `Unwrap() []error`, so [errors.Is] and [errors.As]
+// visit every branch independently. Use this when a sentinel or typed error might only appear
+// in one of the joined errors.
+//
+// Any nil error values are discarded, and nil is returned if no values are left.
+func JoinTree(errs ...error) error {
+	nonNil := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	return lazyerrorTree{
+		errs: nonNil,
+		pcs:  pcs(3, StackDepth),
+	}
+}
+
+// pcs returns up to depth program counters of the caller's caller, skipping skip frames.
+func pcs(skip, depth int) []uintptr {
+	if depth <= 0 {
+		return nil
+	}
+
+	pc := make([]uintptr, depth)
+	n := runtime.Callers(skip, pc)
 
-	return pc[0]
+	return pc[:n]
 }
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"reflect"
 	"runtime"
 	"strings"
@@ -97,34 +98,34 @@ func TestErrors(t *testing.T) {
 	err2 := Errorf("err2: %w", err1)
 	err3 := Errorf("err3: %w", err2)
 
-	expected := "lazyerrors_test.go:95 (lazyerrors.TestErrors): err"
+	expected := "lazyerrors_test.go:96 (lazyerrors.TestErrors): err"
 	assertEqual(t, expected, err.Error())
-	expected = "lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: " +
-		"lazyerrors_test.go:95 (lazyerrors.TestErrors): err"
+	expected = "lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: " +
+		"lazyerrors_test.go:96 (lazyerrors.TestErrors): err"
 	assertEqual(t, expected, err1.Error())
-	expected = "lazyerrors_test.go:97 (lazyerrors.TestErrors): err2: " +
-		"lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: " +
-		"lazyerrors_test.go:95 (lazyerrors.TestErrors): err"
+	expected = "lazyerrors_test.go:98 (lazyerrors.TestErrors): err2: " +
+		"lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: " +
+		"lazyerrors_test.go:96 (lazyerrors.TestErrors): err"
 	assertEqual(t, expected, err2.Error())
-	expected = "lazyerrors_test.go:98 (lazyerrors.TestErrors): err3: " +
-		"lazyerrors_test.go:97 (lazyerrors.TestErrors): err2: " +
-		"lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: " +
-		"lazyerrors_test.go:95 (lazyerrors.TestErrors): err"
+	expected = "lazyerrors_test.go:99 (lazyerrors.TestErrors): err3: " +
+		"lazyerrors_test.go:98 (lazyerrors.TestErrors): err2: " +
+		"lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: " +
+		"lazyerrors_test.go:96 (lazyerrors.TestErrors): err"
 	assertEqual(t, expected, err3.Error())
 
-	expected = `lazyerror{"lazyerrors_test.go:95 (lazyerrors.TestErrors): err"}`
+	expected = `lazyerror{"lazyerrors_test.go:96 (lazyerrors.TestErrors): err"}`
 	assertEqual(t, expected, fmt.Sprintf("%#v", err))
-	expected = `lazyerror{"lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: ` +
-		`lazyerrors_test.go:95 (lazyerrors.TestErrors): err"}`
+	expected = `lazyerror{"lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: ` +
+		`lazyerrors_test.go:96 (lazyerrors.TestErrors): err"}`
 	assertEqual(t, expected, fmt.Sprintf("%#v", err1))
-	expected = `lazyerror{"lazyerrors_test.go:97 (lazyerrors.TestErrors): err2: ` +
-		`lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: ` +
-		`lazyerrors_test.go:95 (lazyerrors.TestErrors): err"}`
+	expected = `lazyerror{"lazyerrors_test.go:98 (lazyerrors.TestErrors): err2: ` +
+		`lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: ` +
+		`lazyerrors_test.go:96 (lazyerrors.TestErrors): err"}`
 	assertEqual(t, expected, fmt.Sprintf("%#v", err2))
-	expected = `lazyerror{"lazyerrors_test.go:98 (lazyerrors.TestErrors): err3: ` +
-		`lazyerrors_test.go:97 (lazyerrors.TestErrors): err2: ` +
-		`lazyerrors_test.go:96 (lazyerrors.TestErrors): err1: ` +
-		`lazyerrors_test.go:95 (lazyerrors.TestErrors): err"}`
+	expected = `lazyerror{"lazyerrors_test.go:99 (lazyerrors.TestErrors): err3: ` +
+		`lazyerrors_test.go:98 (lazyerrors.TestErrors): err2: ` +
+		`lazyerrors_test.go:97 (lazyerrors.TestErrors): err1: ` +
+		`lazyerrors_test.go:96 (lazyerrors.TestErrors): err"}`
 	assertEqual(t, expected, fmt.Sprintf("%#v", err3))
 
 	assertNotEqual(t, err, unwrap(err1, 1))
@@ -154,6 +155,49 @@ func TestErrors(t *testing.T) {
 	assertEqual(t, true, errors.Is(err3, err))
 }
 
+// sentinelError is a test-only error type with a pointer receiver, used to exercise [errors.As].
+type sentinelError struct {
+	msg string
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+func TestJoinTree(t *testing.T) {
+	t.Parallel()
+
+	errA := errors.New("errA")
+	errB := &sentinelError{msg: "errB"}
+	errC := New("errC")
+
+	joined := JoinTree(errA, nil, errB, errC)
+
+	assertEqual(t, true, errors.Is(joined, errA))
+	assertEqual(t, true, errors.Is(joined, errC))
+	assertEqual(t, false, errors.Is(joined, errors.New("errA")))
+
+	var target *sentinelError
+	assertEqual(t, true, errors.As(joined, &target))
+	assertEqual(t, errB, target)
+
+	unwrapper, ok := joined.(interface{ Unwrap() []error })
+	assertEqual(t, true, ok)
+	assertEqual(t, []error{errA, errB, errC}, unwrapper.Unwrap())
+
+	assertEqual(t, true, strings.Contains(joined.Error(), "errA; errB; "))
+	assertEqual(t, true, strings.Contains(joined.Error(), "lazyerrors_test.go"))
+
+	assertEqual(t, true, strings.HasPrefix(fmt.Sprintf("%#v", joined), `lazyerrorTree{"`))
+
+	// Join still collapses to a linear Unwrap() error chain, not Unwrap() []error.
+	linear := Join(errA, errB)
+	_, linearIsTree := linear.(interface{ Unwrap() []error })
+	assertEqual(t, false, linearIsTree)
+
+	assertEqual(t, nil, JoinTree(nil, nil))
+}
+
 func TestPC(t *testing.T) {
 	t.Parallel()
 
@@ -168,7 +212,7 @@ func TestPC(t *testing.T) {
 
 	err := <-ch
 	runtime.Gosched()
-	assertEqual(t, "lazyerrors_test.go:166 (lazyerrors.TestPC.func1): err", err.Error())
+	assertEqual(t, "lazyerrors_test.go:210 (lazyerrors.TestPC.func1): err", err.Error())
 }
 
 // errPackage is a package-level error to test init function call location.
@@ -259,7 +303,7 @@ func BenchmarkNew(b *testing.B) {
 	b.StopTimer()
 
 	assertNotEqual(b, nil, drain)
-	assertEqual(b, "lazyerrors_test.go:235 (lazyerrors.BenchmarkNew): err", drain.Error())
+	assertEqual(b, "lazyerrors_test.go:300 (lazyerrors.BenchmarkNew): err", drain.Error())
 }
 
 func BenchmarkNNew(b *testing.B) {
@@ -272,7 +316,88 @@ func BenchmarkNNew(b *testing.B) {
 	b.StopTimer()
 
 	assertNotEqual(b, nil, drain)
-	assertEqual(b, "lazyerrors_test.go:248 (lazyerrors.BenchmarkNNew): err", drain.Error())
+	assertEqual(b, "lazyerrors_test.go:313 (lazyerrors.BenchmarkNNew): err", drain.Error())
+}
+
+func TestStackDepth(t *testing.T) {
+	t.Parallel()
+
+	err := NewStack("err", 3)
+	le, ok := err.(lazyerror)
+	assertEqual(t, true, ok)
+
+	frames := le.loc()
+	assertEqual(t, true, len(frames) >= 1)
+	assertEqual(t, true, len(frames) <= 3)
+
+	err = ErrorStack(errors.New("err"), 2)
+	le, ok = err.(lazyerror)
+	assertEqual(t, true, ok)
+	assertEqual(t, true, len(le.loc()) <= 2)
+}
+
+func TestFormatPlusV(t *testing.T) {
+	t.Parallel()
+
+	inner := New("inner")
+	outer := Errorf("outer: %w", inner)
+
+	s := fmt.Sprintf("%+v", outer)
+	firstLine := strings.SplitN(s, "\n", 2)[0]
+
+	assertEqual(t, true, strings.Contains(firstLine, "outer: "))
+	assertEqual(t, true, strings.HasSuffix(firstLine, ": inner"))
+	assertEqual(t, true, strings.Contains(s, "lazyerrors.TestFormatPlusV"))
+	assertEqual(t, true, strings.Count(s, "lazyerrors_test.go") >= 2)
+
+	assertEqual(t, outer.Error(), fmt.Sprintf("%v", outer))
+	assertEqual(t, outer.Error(), fmt.Sprintf("%s", outer))
+}
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	err := New("err")
+	le, ok := err.(lazyerror)
+	assertEqual(t, true, ok)
+
+	group := le.LogValue()
+	assertEqual(t, slog.KindGroup, group.Kind())
+
+	attrs := make(map[string]slog.Value, len(group.Group()))
+	for _, a := range group.Group() {
+		attrs[a.Key] = a.Value
+	}
+
+	assertEqual(t, "err", attrs["msg"].String())
+	assertEqual(t, true, strings.HasSuffix(attrs["file"].String(), "lazyerrors_test.go"))
+	assertEqual(t, int64(360), attrs["line"].Int64())
+	assertEqual(t, true, strings.HasSuffix(attrs["func"].String(), "lazyerrors.TestLogValue"))
+	_, hasStack := attrs["stack"]
+	assertEqual(t, false, hasStack)
+
+	stacked := NewStack("err", 2)
+	le, ok = stacked.(lazyerror)
+	assertEqual(t, true, ok)
+
+	for _, a := range le.LogValue().Group() {
+		if a.Key == "stack" {
+			stack, ok := a.Value.Any().([]slog.Value)
+			assertEqual(t, true, ok)
+			assertEqual(t, true, len(stack) >= 1)
+			assertEqual(t, slog.KindGroup, stack[0].Kind())
+
+			frame := make(map[string]slog.Value, len(stack[0].Group()))
+			for _, fa := range stack[0].Group() {
+				frame[fa.Key] = fa.Value
+			}
+
+			assertEqual(t, true, strings.HasSuffix(frame["file"].String(), "lazyerrors_test.go"))
+		}
+	}
+
+	assertEqual(t, true, len(Frames(err)) >= 1)
+	assertEqual(t, []Frame(nil), Frames(errors.New("plain")))
 }
 
 func Example() {
@@ -295,6 +420,33 @@ func Example() {
 	fmt.Println(errors.Is(err, io.EOF))
 
 	// Output:
-	// lazyerrors_test.go:286: lazyerrors_test.go:281: i'm not lazy: EOF
+	// lazyerrors_test.go:411: lazyerrors_test.go:406: i'm not lazy: EOF
 	// true
 }
+
+// TestIsNestedLazyerror ensures [lazyerror.Is] does not panic when le.err is itself
+// a non-comparable lazyerror, as happens when wrapping one lazyerror directly inside
+// another (see [Example]).
+func TestIsNestedLazyerror(t *testing.T) {
+	t.Parallel()
+
+	inner := New("err")
+	mid := Error(inner)
+
+	assertEqual(t, true, errors.Is(mid, mid))
+	assertEqual(t, true, errors.Is(mid, inner))
+}
+
+// TestFramesJoinTree ensures [Frames] descends into [lazyerrorTree] branches
+// produced by [JoinTree] instead of stopping at the tree.
+func TestFramesJoinTree(t *testing.T) {
+	t.Parallel()
+
+	errA := New("errA")
+	errB := New("errB")
+
+	joined := JoinTree(errA, errB)
+
+	// one frame for the JoinTree call itself plus one for each branch.
+	assertEqual(t, 3, len(Frames(joined)))
+}
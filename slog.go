@@ -0,0 +1,106 @@
+// Copyright 2021 FerretDB Inc.
+// Copyright 2025 Alexey Palazhchenko.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lazyerrors
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// Frame holds a single captured call frame's location, exported so logging middleware
+// (Sentry, OTel span events, etc.) can attach it without string-parsing [lazyerror.Error]'s output.
+type Frame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// Frames walks err's wrap chain collecting the location of every [lazyerror] found, outermost first.
+// It descends into [lazyerrorTree] branches (as produced by [JoinTree]) too, so frames inside a joined
+// error are not silently dropped. It returns nil if err is not a [lazyerror] or [lazyerrorTree]
+// and does not wrap one.
+func Frames(err error) []Frame {
+	var res []Frame
+
+	switch e := err.(type) {
+	case lazyerror:
+		for _, frame := range e.loc() {
+			res = append(res, Frame{File: frame.File, Line: frame.Line, Function: frame.Function})
+		}
+	case lazyerrorTree:
+		for _, frame := range framesFor(e.pcs) {
+			res = append(res, Frame{File: frame.File, Line: frame.Line, Function: frame.Function})
+		}
+
+		for _, branch := range e.errs {
+			res = append(res, Frames(branch)...)
+		}
+
+		return res
+	}
+
+	if next := errors.Unwrap(err); next != nil {
+		res = append(res, Frames(next)...)
+	}
+
+	return res
+}
+
+// LogValue implements the [slog.LogValuer] interface.
+//
+// It returns a [slog.GroupValue] with "msg", "file", "line", and "func" attributes for the first
+// captured frame, plus a "stack" attribute listing every captured frame as its own "file"/"line"/"func"
+// group when more than one was captured (see [StackDepth], [NewStack], [ErrorStack]), keeping the same
+// field casing as the outer group. This lets `slog.Error("op failed", "err", err)` produce structured
+// location fields in JSON handlers instead of the flat string from [lazyerror.Error].
+// If no frame was captured (a [StackDepth] of 0 or less), it returns the wrapped error's message
+// as a [slog.StringValue] instead.
+func (le lazyerror) LogValue() slog.Value {
+	frames := le.loc()
+	if len(frames) == 0 {
+		return slog.StringValue(le.err.Error())
+	}
+
+	frame := frames[0]
+
+	attrs := []slog.Attr{
+		slog.String("msg", le.err.Error()),
+		slog.String("file", frame.File),
+		slog.Int("line", frame.Line),
+		slog.String("func", frame.Function),
+	}
+
+	if len(frames) > 1 {
+		stack := make([]slog.Value, len(frames))
+		for i, f := range frames {
+			stack[i] = slog.GroupValue(
+				slog.String("file", f.File),
+				slog.Int("line", f.Line),
+				slog.String("func", f.Function),
+			)
+		}
+
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// check interfaces
+var (
+	_ slog.LogValuer = &lazyerror{}
+	_ slog.LogValuer = lazyerror{}
+)